@@ -0,0 +1,182 @@
+// Package bench runs a query repeatedly under warmup and measures its
+// latency distribution, instead of timing a single db.Query dispatch.
+package bench
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Query runs one query against the database under test.
+type Query func(ctx context.Context) (*sql.Rows, error)
+
+// Options controls how a benchmark is run.
+type Options struct {
+	Warmup      int // iterations run and discarded before measuring
+	Iterations  int // measured iterations
+	Concurrency int // number of goroutines issuing queries concurrently; defaults to 1
+}
+
+// Result summarizes the latency distribution of one benchmarked query.
+type Result struct {
+	Name   string        `json:"name"`
+	Runs   int           `json:"runs"`
+	Rows   int           `json:"rows"` // rows returned by the last iteration
+	Mean   time.Duration `json:"mean_ns"`
+	StdDev time.Duration `json:"stddev_ns"`
+	P50    time.Duration `json:"p50_ns"`
+	P95    time.Duration `json:"p95_ns"`
+	P99    time.Duration `json:"p99_ns"`
+}
+
+func (r Result) String() string {
+	return fmt.Sprintf("%s: runs=%d rows=%d mean=%v stddev=%v p50=%v p95=%v p99=%v",
+		r.Name, r.Runs, r.Rows, r.Mean, r.StdDev, r.P50, r.P95, r.P99)
+}
+
+// Run executes query opts.Warmup times to warm up caches and connections,
+// then opts.Iterations more times across opts.Concurrency workers, fully
+// consuming every row via rows.Scan, and returns the measured latency
+// distribution.
+func Run(ctx context.Context, name string, query Query, opts Options) (Result, error) {
+	if opts.Iterations <= 0 {
+		return Result{}, fmt.Errorf("bench: %s: iterations must be > 0, got %d", name, opts.Iterations)
+	}
+	if opts.Concurrency < 1 {
+		opts.Concurrency = 1
+	}
+
+	for i := 0; i < opts.Warmup; i++ {
+		if _, err := execOnce(ctx, query); err != nil {
+			return Result{}, fmt.Errorf("bench: %s: warmup: %w", name, err)
+		}
+	}
+
+	durations := make([]time.Duration, opts.Iterations)
+	rowCounts := make([]int, opts.Iterations)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var firstErr error
+
+	work := make(chan int)
+	for w := 0; w < opts.Concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range work {
+				start := time.Now()
+				n, err := execOnce(ctx, query)
+				durations[i] = time.Since(start)
+				rowCounts[i] = n
+				if err != nil {
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = err
+					}
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	for i := 0; i < opts.Iterations; i++ {
+		work <- i
+	}
+	close(work)
+	wg.Wait()
+
+	if firstErr != nil {
+		return Result{}, fmt.Errorf("bench: %s: %w", name, firstErr)
+	}
+
+	return summarize(name, durations, rowCounts), nil
+}
+
+// execOnce runs query once and fully consumes the result set, so the
+// measured duration reflects real work rather than query-dispatch latency.
+func execOnce(ctx context.Context, query Query) (int, error) {
+	rows, err := query(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+	dest := make([]any, len(cols))
+	ptrs := make([]any, len(cols))
+	for i := range dest {
+		ptrs[i] = &dest[i]
+	}
+
+	n := 0
+	for rows.Next() {
+		if err := rows.Scan(ptrs...); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, rows.Err()
+}
+
+func summarize(name string, durations []time.Duration, rowCounts []int) Result {
+	if len(durations) == 0 {
+		return Result{Name: name}
+	}
+
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var sum time.Duration
+	for _, d := range sorted {
+		sum += d
+	}
+	mean := sum / time.Duration(len(sorted))
+
+	var variance float64
+	for _, d := range sorted {
+		diff := float64(d - mean)
+		variance += diff * diff
+	}
+	variance /= float64(len(sorted))
+	stddev := time.Duration(math.Sqrt(variance))
+
+	lastRows := 0
+	if len(rowCounts) > 0 {
+		lastRows = rowCounts[len(rowCounts)-1]
+	}
+
+	return Result{
+		Name:   name,
+		Runs:   len(sorted),
+		Rows:   lastRows,
+		Mean:   mean,
+		StdDev: stddev,
+		P50:    percentile(sorted, 50),
+		P95:    percentile(sorted, 95),
+		P99:    percentile(sorted, 99),
+	}
+}
+
+// percentile returns the p-th percentile of sorted using the nearest-rank
+// method; sorted must already be sorted ascending.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := (len(sorted)*p+99)/100 - 1
+	if idx < 0 {
+		idx = 0
+	}
+	if idx >= len(sorted) {
+		idx = len(sorted) - 1
+	}
+	return sorted[idx]
+}
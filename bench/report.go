@@ -0,0 +1,49 @@
+package bench
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"strconv"
+)
+
+// WriteJSON writes results as a JSON array to path.
+func WriteJSON(path string, results []Result) error {
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// WriteCSV writes results as CSV (one row per Result) to path.
+func WriteCSV(path string, results []Result) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	defer w.Flush()
+
+	if err := w.Write([]string{"name", "runs", "rows", "mean_ns", "stddev_ns", "p50_ns", "p95_ns", "p99_ns"}); err != nil {
+		return err
+	}
+	for _, r := range results {
+		row := []string{
+			r.Name,
+			strconv.Itoa(r.Runs),
+			strconv.Itoa(r.Rows),
+			strconv.FormatInt(r.Mean.Nanoseconds(), 10),
+			strconv.FormatInt(r.StdDev.Nanoseconds(), 10),
+			strconv.FormatInt(r.P50.Nanoseconds(), 10),
+			strconv.FormatInt(r.P95.Nanoseconds(), 10),
+			strconv.FormatInt(r.P99.Nanoseconds(), 10),
+		}
+		if err := w.Write(row); err != nil {
+			return err
+		}
+	}
+	return w.Error()
+}
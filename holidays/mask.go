@@ -0,0 +1,65 @@
+// Package holidays decodes the INT holidays column used by the bitflag
+// schema (storage.Backend) into time.Weekday values, and exposes high-level
+// queries built on top of it.
+package holidays
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Mask is a bitmask of time.Weekday values (Sunday=0 .. Saturday=6), matching
+// the encoding storage.Backend writes to the holidays INT column.
+type Mask uint8
+
+// Set marks day as a holiday.
+func (m *Mask) Set(day time.Weekday) {
+	*m |= 1 << uint(day)
+}
+
+// Has reports whether day is marked as a holiday.
+func (m Mask) Has(day time.Weekday) bool {
+	return m&(1<<uint(day)) != 0
+}
+
+// Weekdays returns the days marked as holidays, in week order (Sunday first).
+func (m Mask) Weekdays() []time.Weekday {
+	var days []time.Weekday
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if m.Has(d) {
+			days = append(days, d)
+		}
+	}
+	return days
+}
+
+// String renders the mask as a comma-separated list of weekday names, e.g.
+// "Monday,Friday".
+func (m Mask) String() string {
+	days := m.Weekdays()
+	names := make([]string, len(days))
+	for i, d := range days {
+		names[i] = d.String()
+	}
+	return strings.Join(names, ",")
+}
+
+// Scan implements sql.Scanner, decoding the holidays INT column.
+func (m *Mask) Scan(src any) error {
+	switch v := src.(type) {
+	case int64:
+		*m = Mask(v)
+	case nil:
+		*m = 0
+	default:
+		return fmt.Errorf("holidays: cannot scan %T into Mask", src)
+	}
+	return nil
+}
+
+// Value implements driver.Valuer, encoding the mask back to an INT.
+func (m Mask) Value() (driver.Value, error) {
+	return int64(m), nil
+}
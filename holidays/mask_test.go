@@ -0,0 +1,102 @@
+package holidays
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMaskSetHas(t *testing.T) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		var m Mask
+		if m.Has(d) {
+			t.Fatalf("zero Mask.Has(%s) = true, want false", d)
+		}
+		m.Set(d)
+		if !m.Has(d) {
+			t.Fatalf("Mask.Set(%s) then Has(%s) = false, want true", d, d)
+		}
+		for other := time.Sunday; other <= time.Saturday; other++ {
+			if other != d && m.Has(other) {
+				t.Fatalf("Mask with only %s set also reports Has(%s) = true", d, other)
+			}
+		}
+	}
+}
+
+func TestMaskWeekdaysOrder(t *testing.T) {
+	var m Mask
+	m.Set(time.Friday)
+	m.Set(time.Sunday)
+	m.Set(time.Wednesday)
+
+	got := m.Weekdays()
+	want := []time.Weekday{time.Sunday, time.Wednesday, time.Friday}
+	if len(got) != len(want) {
+		t.Fatalf("Weekdays() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Weekdays() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestMaskString(t *testing.T) {
+	var m Mask
+	m.Set(time.Monday)
+	m.Set(time.Friday)
+	if got, want := m.String(), "Monday,Friday"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got, want := Mask(0).String(), ""; got != want {
+		t.Errorf("String() of empty Mask = %q, want %q", got, want)
+	}
+}
+
+func TestMaskScan(t *testing.T) {
+	var m Mask
+	if err := m.Scan(int64(5)); err != nil {
+		t.Fatalf("Scan(int64(5)) error: %v", err)
+	}
+	if !m.Has(time.Sunday) || !m.Has(time.Tuesday) {
+		t.Errorf("Scan(int64(5)) = %v, want Sunday and Tuesday set", m)
+	}
+
+	m = Mask(1)
+	if err := m.Scan(nil); err != nil {
+		t.Fatalf("Scan(nil) error: %v", err)
+	}
+	if m != 0 {
+		t.Errorf("Scan(nil) left Mask = %v, want 0", m)
+	}
+
+	if err := m.Scan("Monday"); err == nil {
+		t.Error("Scan(string) error = nil, want non-nil")
+	}
+}
+
+func TestMaskValue(t *testing.T) {
+	var m Mask
+	m.Set(time.Saturday)
+	v, err := m.Value()
+	if err != nil {
+		t.Fatalf("Value() error: %v", err)
+	}
+	if v != int64(m) {
+		t.Errorf("Value() = %v, want %v", v, int64(m))
+	}
+}
+
+func TestMaskOf(t *testing.T) {
+	got := maskOf([]time.Weekday{time.Monday, time.Wednesday})
+	if !got.Has(time.Monday) || !got.Has(time.Wednesday) {
+		t.Fatalf("maskOf([Monday, Wednesday]) = %v, missing a set day", got)
+	}
+	if got.Has(time.Tuesday) {
+		t.Fatalf("maskOf([Monday, Wednesday]) = %v, unexpectedly has Tuesday", got)
+	}
+
+	if got := maskOf(nil); got != 0 {
+		t.Errorf("maskOf(nil) = %v, want 0", got)
+	}
+}
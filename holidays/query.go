@@ -0,0 +1,55 @@
+package holidays
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/Fuuma0000/comparison-bitflag/storage"
+)
+
+// Store is one row decoded from the bitflag schema's store_bitflag table.
+type Store struct {
+	ID       int
+	Name     string
+	Holidays Mask
+}
+
+// StoresClosedOn returns the stores closed on at least one of weekdays (ANY
+// semantics), compiling the bitmask WHERE (holidays & ?) > 0 predicate.
+func StoresClosedOn(ctx context.Context, backend storage.Backend, weekdays ...time.Weekday) ([]Store, error) {
+	mask := maskOf(weekdays)
+	return scanStores(backend.QueryByHoliday(ctx, int(mask)))
+}
+
+// StoresClosedOnAll returns the stores closed on every one of weekdays (ALL
+// semantics), compiling the bitmask WHERE (holidays & ?) = ? predicate.
+func StoresClosedOnAll(ctx context.Context, backend storage.Backend, weekdays ...time.Weekday) ([]Store, error) {
+	mask := maskOf(weekdays)
+	return scanStores(backend.QueryByHolidayAll(ctx, int(mask)))
+}
+
+func maskOf(weekdays []time.Weekday) Mask {
+	var mask Mask
+	for _, d := range weekdays {
+		mask.Set(d)
+	}
+	return mask
+}
+
+func scanStores(rows *sql.Rows, err error) ([]Store, error) {
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stores []Store
+	for rows.Next() {
+		var s Store
+		if err := rows.Scan(&s.ID, &s.Name, &s.Holidays); err != nil {
+			return nil, err
+		}
+		stores = append(stores, s)
+	}
+	return stores, rows.Err()
+}
@@ -1,133 +1,107 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
-	_ "github.com/go-sql-driver/mysql"
+	"github.com/spf13/pflag"
 	"golang.org/x/exp/rand"
-)
 
-const (
-	dbUser     = "test_user"
-	dbPassword = "test_pass"
-	dbName     = "test_db"
-	dbHost     = "127.0.0.1"
-	dbPort     = "3306"
+	"github.com/Fuuma0000/comparison-bitflag/bench"
+	"github.com/Fuuma0000/comparison-bitflag/storage"
 )
 
 func main() {
-	// MySQLに接続
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
-		dbUser, dbPassword, dbHost, dbPort, dbName)
-	db, err := sql.Open("mysql", dsn)
-	if err != nil {
-		log.Fatal(err)
+	driver := pflag.String("driver", "mysql", "database driver to benchmark (mysql, postgres, sqlite3)")
+	dsn := pflag.String("dsn", "test_user:test_pass@tcp(127.0.0.1:3306)/test_db?charset=utf8mb4&parseTime=True&loc=Local", "data source name, overridden by -config if set")
+	configPath := pflag.String("config", "", "path to a JSON config file ({\"driver\":...,\"dsn\":...}), overrides -driver/-dsn")
+	numStores := pflag.Int("rows", 1000000, "number of stores to insert")
+	batchSize := pflag.Int("batch", 1000, "number of stores per multi-VALUES insert statement")
+	warmup := pflag.Int("warmup", 5, "warmup iterations per query, discarded before measuring")
+	iterations := pflag.Int("iterations", 20, "measured iterations per query")
+	concurrency := pflag.Int("concurrency", 1, "concurrent workers issuing each query")
+	output := pflag.String("output", "", "path to write benchmark results to (format inferred from extension: .json or .csv)")
+	pflag.Parse()
+
+	if *configPath != "" {
+		cfg, err := storage.LoadConfig(*configPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		*driver = cfg.Driver
+		*dsn = cfg.DSN
 	}
-	defer db.Close()
 
-	// テーブルを削除
-	_, err = db.Exec("DROP TABLE IF EXISTS store_bitflag")
-	if err != nil {
-		log.Fatal(err)
-	}
-	_, err = db.Exec("DROP TABLE IF EXISTS store_holiday")
-	if err != nil {
-		log.Fatal(err)
-	}
-	_, err = db.Exec("DROP TABLE IF EXISTS store")
+	backend, err := storage.Open(*driver, *dsn)
 	if err != nil {
 		log.Fatal(err)
 	}
+	defer backend.Close()
+
+	ctx := context.Background()
 
 	// テーブル作成
-	createTables(db)
+	if err := backend.CreateSchema(ctx); err != nil {
+		log.Fatal(err)
+	}
 
 	// データ挿入
-	numStores := 1000000 // 100万店舗をテスト
 	fmt.Println("Inserting data...")
-	insertTestData(db, numStores)
+	insertTestData(ctx, backend, *numStores, *batchSize)
 
 	// パフォーマンステスト
 	fmt.Println("\nRunning performance tests...")
-	benchmarkSelectAll(db)
-	benchmarkSelectMonday(db)
-}
-
-func createTables(db *sql.DB) {
-	// 店舗テーブル（store）
-	_, err := db.Exec(`
-		CREATE TABLE IF NOT EXISTS store (
-			id INT AUTO_INCREMENT PRIMARY KEY,
-			name VARCHAR(255) NOT NULL
-		)
-	`)
-	if err != nil {
-		log.Fatal(err)
+	opts := bench.Options{Warmup: *warmup, Iterations: *iterations, Concurrency: *concurrency}
+	results := runBenchmarks(ctx, backend, opts)
+	for _, r := range results {
+		fmt.Println(r)
 	}
 
-	// 定休日テーブル（store_holiday）
-	_, err = db.Exec(`
-		CREATE TABLE IF NOT EXISTS store_holiday (
-			id INT AUTO_INCREMENT PRIMARY KEY,
-			store_id INT NOT NULL,
-			day_of_week ENUM('Sunday', 'Monday', 'Tuesday', 'Wednesday', 'Thursday', 'Friday', 'Saturday') NOT NULL,
-			FOREIGN KEY (store_id) REFERENCES store(id) ON DELETE CASCADE
-		)
-	`)
-	if err != nil {
-		log.Fatal(err)
+	if *output != "" {
+		if err := writeResults(*output, results); err != nil {
+			log.Fatal(err)
+		}
 	}
+}
 
-	// ビットフラグテーブル（store_bitflag）
-	_, err = db.Exec(`
-		CREATE TABLE IF NOT EXISTS store_bitflag (
-			id INT AUTO_INCREMENT PRIMARY KEY,
-			name VARCHAR(255) NOT NULL,
-			holidays INT NOT NULL
-		)
-	`)
-	if err != nil {
-		log.Fatal(err)
+func insertTestData(ctx context.Context, backend storage.Backend, numStores, batchSize int) {
+	if batchSize <= 0 {
+		log.Fatalf("insertTestData: -batch must be > 0, got %d", batchSize)
 	}
-}
 
-func insertTestData(db *sql.DB, numStores int) {
 	// ランダムシードを設定
 	rand.Seed(uint64(time.Now().UnixNano()))
 
 	// 曜日のリスト
 	daysOfWeek := []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}
 
-	// 別テーブル（正規化）
 	start := time.Now()
-	tx, _ := db.Begin()
+	batch := make([]storage.StoreInput, 0, batchSize)
 	for i := 1; i <= numStores; i++ {
-		// 店舗を追加
-		_, _ = tx.Exec("INSERT INTO store (name) VALUES (?)", fmt.Sprintf("Store %d", i))
-		storeID := i
-
 		// ランダムに定休日の数を決定（1〜7個）
 		numHolidays := rand.Intn(7) + 1
 		selectedDays := randomDays(daysOfWeek, numHolidays)
 
-		// 選ばれた定休日を `store_holiday` にINSERT
-		for _, day := range selectedDays {
-			_, _ = tx.Exec("INSERT INTO store_holiday (store_id, day_of_week) VALUES (?, ?)", storeID, day)
-		}
-
-		// 選ばれた曜日のビットフラグを作成し、ビットフラグテーブルにも同じデータを格納
-		holidays := calculateBitFlag(selectedDays)
-		_, _ = tx.Exec("INSERT INTO store_bitflag (name, holidays) VALUES (?, ?)", fmt.Sprintf("Store %d", i), holidays)
-
-		if i%10000 == 0 {
+		batch = append(batch, storage.StoreInput{
+			Name:         fmt.Sprintf("Store %d", i),
+			HolidayNames: selectedDays,
+			HolidayMask:  calculateBitFlag(selectedDays),
+		})
+
+		if len(batch) == batchSize || i == numStores {
+			if err := backend.InsertBatch(ctx, batch); err != nil {
+				log.Fatal(err)
+			}
+			batch = batch[:0]
 			fmt.Print("現在:", i)
 		}
 	}
-	tx.Commit()
-	fmt.Printf("INSERT (別テーブル & ビットフラグ) 完了: %v\n", time.Since(start))
+	elapsed := time.Since(start)
+	fmt.Printf("\nINSERT (別テーブル & ビットフラグ) 完了: %v (%.0f rows/sec)\n", elapsed, float64(numStores)/elapsed.Seconds())
 }
 
 // 指定された曜日リストからランダムに `n` 個の曜日を選ぶ
@@ -151,24 +125,53 @@ func calculateBitFlag(selectedDays []string) int {
 	return holidays
 }
 
-func benchmarkSelectAll(db *sql.DB) {
-	// 全店舗の定休日を取得
-	start := time.Now()
-	_, _ = db.Query("SELECT s.id, s.name, GROUP_CONCAT(h.day_of_week) FROM store s LEFT JOIN store_holiday h ON s.id = h.store_id GROUP BY s.id")
-	fmt.Printf("SELECT ALL (別テーブル) 完了: %v\n", time.Since(start))
+const allDaysMask = 0b1111111 // Sunday..Saturday
+
+// runBenchmarks runs every comparison query through the bench harness
+// (warmup, repeated runs, percentile reporting) and returns one Result per
+// query. The SET comparison is skipped on backends that don't support it.
+func runBenchmarks(ctx context.Context, backend storage.Backend, opts bench.Options) []bench.Result {
+	queries := []struct {
+		name  string
+		query bench.Query
+	}{
+		{"select_all_normalized", backend.QueryAll},
+		{"select_all_bitflag", func(ctx context.Context) (*sql.Rows, error) {
+			return backend.QueryByHoliday(ctx, allDaysMask)
+		}},
+		{"select_monday_normalized", func(ctx context.Context) (*sql.Rows, error) {
+			return backend.QueryByHolidayNormalized(ctx, "Monday")
+		}},
+		{"select_monday_bitflag", func(ctx context.Context) (*sql.Rows, error) {
+			return backend.QueryByHoliday(ctx, 2)
+		}},
+	}
 
-	start = time.Now()
-	_, _ = db.Query("SELECT id, name, holidays FROM store_bitflag")
-	fmt.Printf("SELECT ALL (ビットフラグ) 完了: %v\n", time.Since(start))
-}
+	if setBackend, ok := backend.(storage.SetBackend); ok {
+		queries = append(queries, struct {
+			name  string
+			query bench.Query
+		}{"select_monday_set", func(ctx context.Context) (*sql.Rows, error) {
+			return setBackend.QueryByHolidaySet(ctx, "Monday")
+		}})
+	}
 
-func benchmarkSelectMonday(db *sql.DB) {
-	// 特定の曜日（Monday）の定休日店舗を取得
-	start := time.Now()
-	_, _ = db.Query("SELECT s.* FROM store s JOIN store_holiday h ON s.id = h.store_id WHERE h.day_of_week = 'Monday'")
-	fmt.Printf("SELECT WHERE Monday (別テーブル) 完了: %v\n", time.Since(start))
+	results := make([]bench.Result, 0, len(queries))
+	for _, q := range queries {
+		r, err := bench.Run(ctx, q.name, q.query, opts)
+		if err != nil {
+			log.Fatal(err)
+		}
+		results = append(results, r)
+	}
+	return results
+}
 
-	start = time.Now()
-	_, _ = db.Query("SELECT * FROM store_bitflag WHERE (holidays & 2) > 0")
-	fmt.Printf("SELECT WHERE Monday (ビットフラグ) 完了: %v\n", time.Since(start))
+// writeResults writes results to path in JSON or CSV, inferred from path's
+// extension.
+func writeResults(path string, results []bench.Result) error {
+	if strings.HasSuffix(path, ".csv") {
+		return bench.WriteCSV(path, results)
+	}
+	return bench.WriteJSON(path, results)
 }
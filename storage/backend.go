@@ -0,0 +1,156 @@
+// Package storage abstracts the SQL needed to run the normalized-vs-bitflag
+// benchmark against different database engines. Bitmask semantics (and the
+// placeholder syntax used to reach them) differ across engines, so each
+// engine gets its own Backend implementation instead of sharing raw SQL.
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// StoreInput is one row to insert via Backend.InsertBatch.
+type StoreInput struct {
+	Name         string
+	HolidayNames []string
+	HolidayMask  int
+}
+
+// Backend is implemented once per supported database engine.
+type Backend interface {
+	// CreateSchema (re)creates the store, store_holiday and store_bitflag
+	// tables, dropping any existing ones first. This is the benchmark's own
+	// bootstrap path and is intentionally not sourced from schema.sql:
+	// cmd/schema-dump reads its DDL back out of a database CreateSchema has
+	// already created. See cmd/migrate for reproducing that schema elsewhere.
+	CreateSchema(ctx context.Context) error
+
+	// InsertBatch inserts a batch of stores, together with their normalized
+	// holidays (store_holiday) and bitflag holidays (store_bitflag), using a
+	// single multi-VALUES statement per table instead of one round trip per
+	// row.
+	InsertBatch(ctx context.Context, stores []StoreInput) error
+
+	// QueryAll returns every store joined with its normalized holidays.
+	QueryAll(ctx context.Context) (*sql.Rows, error)
+
+	// QueryByHolidayNormalized returns every store closed on day via the
+	// normalized store_holiday join, the table-per-day counterpart to
+	// QueryByHoliday's bitmask comparison.
+	QueryByHolidayNormalized(ctx context.Context, day string) (*sql.Rows, error)
+
+	// QueryByHoliday returns every bitflag store closed on the given bit
+	// (ANY semantics: at least one bit of mask is set).
+	QueryByHoliday(ctx context.Context, bit int) (*sql.Rows, error)
+
+	// QueryByHolidayAll returns every bitflag store closed on every day in
+	// mask (ALL semantics), unlike QueryByHoliday's ANY semantics.
+	QueryByHolidayAll(ctx context.Context, mask int) (*sql.Rows, error)
+
+	// Close releases the underlying database handle.
+	Close() error
+}
+
+// SchemaDumper is an optional capability implemented by backends that can
+// reflect their own DDL back as CREATE TABLE statements (SHOW CREATE TABLE
+// on MySQL, sqlite_master on SQLite). Postgres has no single-query
+// equivalent, so it does not implement this; use pg_dump instead.
+type SchemaDumper interface {
+	// DumpSchema returns one CREATE TABLE statement per table in tables, in
+	// the same order.
+	DumpSchema(ctx context.Context, tables []string) ([]string, error)
+}
+
+// SetBackend is an optional capability implemented by backends that also
+// maintain a native SET-typed holidays column (store_set) as a fourth point
+// of comparison alongside the normalized and bitflag approaches. Only MySQL
+// supports this today, so callers should type-assert for it.
+type SetBackend interface {
+	// QueryByHolidaySet returns every store_set row closed on day.
+	QueryByHolidaySet(ctx context.Context, day string) (*sql.Rows, error)
+}
+
+// placeholderRows returns n comma-separated groups of cols "?" placeholders,
+// e.g. placeholderRows(2, 2) = "(?, ?), (?, ?)". It is shared by the mysql
+// and sqlite3 backends, which both use "?" placeholders.
+func placeholderRows(n, cols int) string {
+	row := "(" + strings.TrimSuffix(strings.Repeat("?, ", cols), ", ") + ")"
+	rows := make([]string, n)
+	for i := range rows {
+		rows[i] = row
+	}
+	return strings.Join(rows, ", ")
+}
+
+// placeholderRowsFrom returns n comma-separated groups of cols "$n"
+// placeholders numbered from startAt, e.g. placeholderRowsFrom(1, 2, 2) =
+// "($1, $2), ($3, $4)". It is used by the postgres backend.
+func placeholderRowsFrom(startAt, n, cols int) string {
+	rows := make([]string, n)
+	next := startAt
+	for i := range rows {
+		cells := make([]string, cols)
+		for c := range cells {
+			cells[c] = fmt.Sprintf("$%d", next)
+			next++
+		}
+		rows[i] = "(" + strings.Join(cells, ", ") + ")"
+	}
+	return strings.Join(rows, ", ")
+}
+
+// preparedStmt returns a cached prepared statement for a batch of n rows,
+// building and caching one with build(n) on first use. It is shared by all
+// three backends' InsertBatch, which all cache per-table statements keyed by
+// batch size.
+func preparedStmt(ctx context.Context, db *sql.DB, cache map[int]*sql.Stmt, n int, build func(n int) string) (*sql.Stmt, error) {
+	if stmt, ok := cache[n]; ok {
+		return stmt, nil
+	}
+	stmt, err := db.PrepareContext(ctx, build(n))
+	if err != nil {
+		return nil, err
+	}
+	cache[n] = stmt
+	return stmt, nil
+}
+
+// closeStmtCaches closes every prepared statement in caches, ignoring
+// individual close errors so Backend.Close can still close the underlying
+// *sql.DB afterward.
+func closeStmtCaches(caches ...map[int]*sql.Stmt) {
+	for _, cache := range caches {
+		for _, stmt := range cache {
+			stmt.Close()
+		}
+	}
+}
+
+// Open connects to the database identified by driver/dsn and returns the
+// matching Backend. driver must be one of "mysql", "postgres" or "sqlite3".
+func Open(driver, dsn string) (Backend, error) {
+	switch driver {
+	case "mysql":
+		return newMySQLBackend(dsn)
+	case "postgres":
+		return newPostgresBackend(dsn)
+	case "sqlite3":
+		return newSQLiteBackend(dsn)
+	default:
+		return nil, fmt.Errorf("storage: unknown driver %q", driver)
+	}
+}
+
+// OpenDB connects to the database identified by driver/dsn and returns the
+// raw *sql.DB, for tools like cmd/migrate that execute arbitrary SQL rather
+// than going through Backend.
+func OpenDB(driver, dsn string) (*sql.DB, error) {
+	switch driver {
+	case "mysql", "postgres", "sqlite3":
+		return sql.Open(driver, dsn)
+	default:
+		return nil, fmt.Errorf("storage: unknown driver %q", driver)
+	}
+}
@@ -0,0 +1,195 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	_ "github.com/go-sql-driver/mysql"
+)
+
+type mysqlBackend struct {
+	db *sql.DB
+
+	storeStmts   map[int]*sql.Stmt
+	bitflagStmts map[int]*sql.Stmt
+	setStmts     map[int]*sql.Stmt
+}
+
+func newMySQLBackend(dsn string) (Backend, error) {
+	db, err := sql.Open("mysql", dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &mysqlBackend{
+		db:           db,
+		storeStmts:   make(map[int]*sql.Stmt),
+		bitflagStmts: make(map[int]*sql.Stmt),
+		setStmts:     make(map[int]*sql.Stmt),
+	}, nil
+}
+
+func (b *mysqlBackend) CreateSchema(ctx context.Context) error {
+	for _, stmt := range []string{
+		"DROP TABLE IF EXISTS store_set",
+		"DROP TABLE IF EXISTS store_bitflag",
+		"DROP TABLE IF EXISTS store_holiday",
+		"DROP TABLE IF EXISTS store",
+		`CREATE TABLE store (
+			id INT AUTO_INCREMENT PRIMARY KEY,
+			name VARCHAR(255) NOT NULL
+		)`,
+		`CREATE TABLE store_holiday (
+			id INT AUTO_INCREMENT PRIMARY KEY,
+			store_id INT NOT NULL,
+			day_of_week ENUM('Sunday', 'Monday', 'Tuesday', 'Wednesday', 'Thursday', 'Friday', 'Saturday') NOT NULL,
+			FOREIGN KEY (store_id) REFERENCES store(id) ON DELETE CASCADE
+		)`,
+		`CREATE TABLE store_bitflag (
+			id INT AUTO_INCREMENT PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			holidays INT NOT NULL
+		)`,
+		`CREATE TABLE store_set (
+			id INT AUTO_INCREMENT PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			holidays SET('Sunday', 'Monday', 'Tuesday', 'Wednesday', 'Thursday', 'Friday', 'Saturday') NOT NULL
+		)`,
+	} {
+		if _, err := b.db.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// InsertBatch inserts a batch of stores using one multi-VALUES statement per
+// table, reusing prepared statements (see the shared preparedStmt helper)
+// across calls with the same batch size. store_holiday is exempt since its
+// row count varies per batch (each store has a random number of holidays),
+// so it is built and executed directly.
+func (b *mysqlBackend) InsertBatch(ctx context.Context, stores []StoreInput) error {
+	if len(stores) == 0 {
+		return nil
+	}
+
+	tx, err := b.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	storeStmt, err := preparedStmt(ctx, b.db, b.storeStmts, len(stores), func(n int) string {
+		return "INSERT INTO store (name) VALUES " + placeholderRows(n, 1)
+	})
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	storeArgs := make([]any, len(stores))
+	for i, s := range stores {
+		storeArgs[i] = s.Name
+	}
+	res, err := tx.StmtContext(ctx, storeStmt).ExecContext(ctx, storeArgs...)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	firstID, err := res.LastInsertId()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	var holidayArgs []any
+	holidayRows := 0
+	for i, s := range stores {
+		storeID := firstID + int64(i)
+		for _, day := range s.HolidayNames {
+			holidayArgs = append(holidayArgs, storeID, day)
+			holidayRows++
+		}
+	}
+	if holidayRows > 0 {
+		query := "INSERT INTO store_holiday (store_id, day_of_week) VALUES " + placeholderRows(holidayRows, 2)
+		if _, err := tx.ExecContext(ctx, query, holidayArgs...); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	bitflagStmt, err := preparedStmt(ctx, b.db, b.bitflagStmts, len(stores), func(n int) string {
+		return "INSERT INTO store_bitflag (name, holidays) VALUES " + placeholderRows(n, 2)
+	})
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	bitflagArgs := make([]any, 0, len(stores)*2)
+	for _, s := range stores {
+		bitflagArgs = append(bitflagArgs, s.Name, s.HolidayMask)
+	}
+	if _, err := tx.StmtContext(ctx, bitflagStmt).ExecContext(ctx, bitflagArgs...); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	setStmt, err := preparedStmt(ctx, b.db, b.setStmts, len(stores), func(n int) string {
+		return "INSERT INTO store_set (name, holidays) VALUES " + placeholderRows(n, 2)
+	})
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	setArgs := make([]any, 0, len(stores)*2)
+	for _, s := range stores {
+		setArgs = append(setArgs, s.Name, strings.Join(s.HolidayNames, ","))
+	}
+	if _, err := tx.StmtContext(ctx, setStmt).ExecContext(ctx, setArgs...); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (b *mysqlBackend) QueryAll(ctx context.Context) (*sql.Rows, error) {
+	return b.db.QueryContext(ctx, "SELECT s.id, s.name, GROUP_CONCAT(h.day_of_week) FROM store s LEFT JOIN store_holiday h ON s.id = h.store_id GROUP BY s.id")
+}
+
+func (b *mysqlBackend) QueryByHoliday(ctx context.Context, bit int) (*sql.Rows, error) {
+	return b.db.QueryContext(ctx, "SELECT id, name, holidays FROM store_bitflag WHERE (holidays & ?) > 0", bit)
+}
+
+func (b *mysqlBackend) QueryByHolidayAll(ctx context.Context, mask int) (*sql.Rows, error) {
+	return b.db.QueryContext(ctx, "SELECT id, name, holidays FROM store_bitflag WHERE (holidays & ?) = ?", mask, mask)
+}
+
+func (b *mysqlBackend) QueryByHolidayNormalized(ctx context.Context, day string) (*sql.Rows, error) {
+	return b.db.QueryContext(ctx, "SELECT s.id, s.name FROM store s JOIN store_holiday h ON s.id = h.store_id WHERE h.day_of_week = ?", day)
+}
+
+func (b *mysqlBackend) Close() error {
+	closeStmtCaches(b.storeStmts, b.bitflagStmts, b.setStmts)
+	return b.db.Close()
+}
+
+// QueryByHolidaySet implements SetBackend, querying the MySQL SET-typed
+// store_set table with FIND_IN_SET instead of a bitmask comparison.
+func (b *mysqlBackend) QueryByHolidaySet(ctx context.Context, day string) (*sql.Rows, error) {
+	return b.db.QueryContext(ctx, "SELECT id, name, holidays FROM store_set WHERE FIND_IN_SET(?, holidays) > 0", day)
+}
+
+// DumpSchema implements SchemaDumper using SHOW CREATE TABLE.
+func (b *mysqlBackend) DumpSchema(ctx context.Context, tables []string) ([]string, error) {
+	ddls := make([]string, 0, len(tables))
+	for _, table := range tables {
+		var name, ddl string
+		row := b.db.QueryRowContext(ctx, fmt.Sprintf("SHOW CREATE TABLE `%s`", table))
+		if err := row.Scan(&name, &ddl); err != nil {
+			return nil, fmt.Errorf("storage: show create table %s: %w", table, err)
+		}
+		ddls = append(ddls, ddl+";")
+	}
+	return ddls, nil
+}
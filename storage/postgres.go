@@ -0,0 +1,161 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+
+	_ "github.com/lib/pq"
+)
+
+type postgresBackend struct {
+	db *sql.DB
+
+	storeStmts   map[int]*sql.Stmt
+	bitflagStmts map[int]*sql.Stmt
+}
+
+func newPostgresBackend(dsn string) (Backend, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &postgresBackend{
+		db:           db,
+		storeStmts:   make(map[int]*sql.Stmt),
+		bitflagStmts: make(map[int]*sql.Stmt),
+	}, nil
+}
+
+func (b *postgresBackend) CreateSchema(ctx context.Context) error {
+	for _, stmt := range []string{
+		"DROP TABLE IF EXISTS store_bitflag",
+		"DROP TABLE IF EXISTS store_holiday",
+		"DROP TABLE IF EXISTS store",
+		`CREATE TABLE store (
+			id SERIAL PRIMARY KEY,
+			name VARCHAR(255) NOT NULL
+		)`,
+		`CREATE TABLE store_holiday (
+			id SERIAL PRIMARY KEY,
+			store_id INT NOT NULL REFERENCES store(id) ON DELETE CASCADE,
+			day_of_week VARCHAR(9) NOT NULL CHECK (day_of_week IN (
+				'Sunday', 'Monday', 'Tuesday', 'Wednesday', 'Thursday', 'Friday', 'Saturday'
+			))
+		)`,
+		`CREATE TABLE store_bitflag (
+			id SERIAL PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			holidays INT NOT NULL
+		)`,
+	} {
+		if _, err := b.db.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// InsertBatch inserts a batch of stores using one multi-VALUES statement per
+// table, reusing prepared statements (see the shared preparedStmt helper)
+// across calls with the same batch size. store_holiday is exempt since its
+// row count varies per batch (each store has a random number of holidays),
+// so it is built and executed directly.
+// Postgres has no LastInsertId, so the store batch uses RETURNING id instead.
+func (b *postgresBackend) InsertBatch(ctx context.Context, stores []StoreInput) error {
+	if len(stores) == 0 {
+		return nil
+	}
+
+	tx, err := b.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	storeStmt, err := preparedStmt(ctx, b.db, b.storeStmts, len(stores), func(n int) string {
+		return "INSERT INTO store (name) VALUES " + placeholderRowsFrom(1, n, 1) + " RETURNING id"
+	})
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	storeArgs := make([]any, len(stores))
+	for i, s := range stores {
+		storeArgs[i] = s.Name
+	}
+	rows, err := tx.StmtContext(ctx, storeStmt).QueryContext(ctx, storeArgs...)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	storeIDs := make([]int64, 0, len(stores))
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			tx.Rollback()
+			return err
+		}
+		storeIDs = append(storeIDs, id)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		tx.Rollback()
+		return err
+	}
+	rows.Close()
+
+	var holidayArgs []any
+	holidayRows := 0
+	for i, s := range stores {
+		for _, day := range s.HolidayNames {
+			holidayArgs = append(holidayArgs, storeIDs[i], day)
+			holidayRows++
+		}
+	}
+	if holidayRows > 0 {
+		query := "INSERT INTO store_holiday (store_id, day_of_week) VALUES " + placeholderRowsFrom(1, holidayRows, 2)
+		if _, err := tx.ExecContext(ctx, query, holidayArgs...); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	bitflagStmt, err := preparedStmt(ctx, b.db, b.bitflagStmts, len(stores), func(n int) string {
+		return "INSERT INTO store_bitflag (name, holidays) VALUES " + placeholderRowsFrom(1, n, 2)
+	})
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	bitflagArgs := make([]any, 0, len(stores)*2)
+	for _, s := range stores {
+		bitflagArgs = append(bitflagArgs, s.Name, s.HolidayMask)
+	}
+	if _, err := tx.StmtContext(ctx, bitflagStmt).ExecContext(ctx, bitflagArgs...); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (b *postgresBackend) QueryAll(ctx context.Context) (*sql.Rows, error) {
+	return b.db.QueryContext(ctx, "SELECT s.id, s.name, STRING_AGG(h.day_of_week, ',') FROM store s LEFT JOIN store_holiday h ON s.id = h.store_id GROUP BY s.id")
+}
+
+func (b *postgresBackend) QueryByHoliday(ctx context.Context, bit int) (*sql.Rows, error) {
+	return b.db.QueryContext(ctx, "SELECT id, name, holidays FROM store_bitflag WHERE (holidays & $1) > 0", bit)
+}
+
+func (b *postgresBackend) QueryByHolidayAll(ctx context.Context, mask int) (*sql.Rows, error) {
+	return b.db.QueryContext(ctx, "SELECT id, name, holidays FROM store_bitflag WHERE (holidays & $1) = $2", mask, mask)
+}
+
+func (b *postgresBackend) QueryByHolidayNormalized(ctx context.Context, day string) (*sql.Rows, error) {
+	return b.db.QueryContext(ctx, "SELECT s.id, s.name FROM store s JOIN store_holiday h ON s.id = h.store_id WHERE h.day_of_week = $1", day)
+}
+
+func (b *postgresBackend) Close() error {
+	closeStmtCaches(b.storeStmts, b.bitflagStmts)
+	return b.db.Close()
+}
@@ -0,0 +1,169 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+type sqliteBackend struct {
+	db *sql.DB
+
+	storeStmts   map[int]*sql.Stmt
+	bitflagStmts map[int]*sql.Stmt
+}
+
+func newSQLiteBackend(dsn string) (Backend, error) {
+	db, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &sqliteBackend{
+		db:           db,
+		storeStmts:   make(map[int]*sql.Stmt),
+		bitflagStmts: make(map[int]*sql.Stmt),
+	}, nil
+}
+
+func (b *sqliteBackend) CreateSchema(ctx context.Context) error {
+	for _, stmt := range []string{
+		"DROP TABLE IF EXISTS store_bitflag",
+		"DROP TABLE IF EXISTS store_holiday",
+		"DROP TABLE IF EXISTS store",
+		`CREATE TABLE store (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL
+		)`,
+		`CREATE TABLE store_holiday (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			store_id INTEGER NOT NULL REFERENCES store(id) ON DELETE CASCADE,
+			day_of_week TEXT NOT NULL CHECK (day_of_week IN (
+				'Sunday', 'Monday', 'Tuesday', 'Wednesday', 'Thursday', 'Friday', 'Saturday'
+			))
+		)`,
+		`CREATE TABLE store_bitflag (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL,
+			holidays INTEGER NOT NULL
+		)`,
+	} {
+		if _, err := b.db.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// InsertBatch inserts a batch of stores using one multi-VALUES statement per
+// table, reusing prepared statements (see the shared preparedStmt helper)
+// across calls with the same batch size. store_holiday is exempt since its
+// row count varies per batch (each store has a random number of holidays),
+// so it is built and executed directly.
+func (b *sqliteBackend) InsertBatch(ctx context.Context, stores []StoreInput) error {
+	if len(stores) == 0 {
+		return nil
+	}
+
+	tx, err := b.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	storeStmt, err := preparedStmt(ctx, b.db, b.storeStmts, len(stores), func(n int) string {
+		return "INSERT INTO store (name) VALUES " + placeholderRows(n, 1)
+	})
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	storeArgs := make([]any, len(stores))
+	for i, s := range stores {
+		storeArgs[i] = s.Name
+	}
+	res, err := tx.StmtContext(ctx, storeStmt).ExecContext(ctx, storeArgs...)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	// sqlite3_last_insert_rowid() returns the rowid of the LAST row
+	// inserted, so the batch's first id is lastID - n + 1.
+	lastID, err := res.LastInsertId()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	firstID := lastID - int64(len(stores)) + 1
+
+	var holidayArgs []any
+	holidayRows := 0
+	for i, s := range stores {
+		storeID := firstID + int64(i)
+		for _, day := range s.HolidayNames {
+			holidayArgs = append(holidayArgs, storeID, day)
+			holidayRows++
+		}
+	}
+	if holidayRows > 0 {
+		query := "INSERT INTO store_holiday (store_id, day_of_week) VALUES " + placeholderRows(holidayRows, 2)
+		if _, err := tx.ExecContext(ctx, query, holidayArgs...); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	bitflagStmt, err := preparedStmt(ctx, b.db, b.bitflagStmts, len(stores), func(n int) string {
+		return "INSERT INTO store_bitflag (name, holidays) VALUES " + placeholderRows(n, 2)
+	})
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	bitflagArgs := make([]any, 0, len(stores)*2)
+	for _, s := range stores {
+		bitflagArgs = append(bitflagArgs, s.Name, s.HolidayMask)
+	}
+	if _, err := tx.StmtContext(ctx, bitflagStmt).ExecContext(ctx, bitflagArgs...); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (b *sqliteBackend) QueryAll(ctx context.Context) (*sql.Rows, error) {
+	return b.db.QueryContext(ctx, "SELECT s.id, s.name, GROUP_CONCAT(h.day_of_week) FROM store s LEFT JOIN store_holiday h ON s.id = h.store_id GROUP BY s.id")
+}
+
+func (b *sqliteBackend) QueryByHoliday(ctx context.Context, bit int) (*sql.Rows, error) {
+	return b.db.QueryContext(ctx, "SELECT id, name, holidays FROM store_bitflag WHERE (holidays & ?) > 0", bit)
+}
+
+func (b *sqliteBackend) QueryByHolidayAll(ctx context.Context, mask int) (*sql.Rows, error) {
+	return b.db.QueryContext(ctx, "SELECT id, name, holidays FROM store_bitflag WHERE (holidays & ?) = ?", mask, mask)
+}
+
+func (b *sqliteBackend) QueryByHolidayNormalized(ctx context.Context, day string) (*sql.Rows, error) {
+	return b.db.QueryContext(ctx, "SELECT s.id, s.name FROM store s JOIN store_holiday h ON s.id = h.store_id WHERE h.day_of_week = ?", day)
+}
+
+// DumpSchema implements SchemaDumper by reading the CREATE TABLE text SQLite
+// stores for each table in sqlite_master.
+func (b *sqliteBackend) DumpSchema(ctx context.Context, tables []string) ([]string, error) {
+	ddls := make([]string, 0, len(tables))
+	for _, table := range tables {
+		var ddl string
+		row := b.db.QueryRowContext(ctx, "SELECT sql FROM sqlite_master WHERE type = 'table' AND name = ?", table)
+		if err := row.Scan(&ddl); err != nil {
+			return nil, fmt.Errorf("storage: read schema for %s: %w", table, err)
+		}
+		ddls = append(ddls, ddl+";")
+	}
+	return ddls, nil
+}
+
+func (b *sqliteBackend) Close() error {
+	closeStmtCaches(b.storeStmts, b.bitflagStmts)
+	return b.db.Close()
+}